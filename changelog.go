@@ -0,0 +1,44 @@
+package diff
+
+// Change types.
+const (
+	CREATE = "create"
+	UPDATE = "update"
+	DELETE = "delete"
+)
+
+// Change represents a single difference between two values, located at Path.
+type Change struct {
+	Type string      `json:"type"`
+	Path Path        `json:"path"`
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+// Changelog is a list of changes produced by Diff, in the order they were
+// discovered while walking the compared values.
+type Changelog []Change
+
+// Filter returns the subset of cl whose Path matches pattern (see
+// Path.Match), preserving order.
+func (cl Changelog) Filter(pattern string) Changelog {
+	out := make(Changelog, 0, len(cl))
+	for _, c := range cl {
+		if c.Path.Match(pattern) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// FilterOut returns the subset of cl whose Path does not match pattern
+// (see Path.Match), preserving order.
+func (cl Changelog) FilterOut(pattern string) Changelog {
+	out := make(Changelog, 0, len(cl))
+	for _, c := range cl {
+		if !c.Path.Match(pattern) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
@@ -0,0 +1,189 @@
+// Package diff walks two values of the same type via reflection and
+// produces a Changelog describing how to turn the first into the second.
+package diff
+
+import "reflect"
+
+// DefaultTagName is the struct tag key this package reads by default.
+const DefaultTagName = "diff"
+
+// Differ walks two values and produces a Changelog. The zero value is not
+// usable; construct one with NewDiffer.
+type Differ struct {
+	tagName                string
+	sliceOrdering          SliceOrdering
+	comparators            map[reflect.Type]Comparator
+	floatTolerance         float64
+	convertCompatibleTypes bool
+}
+
+// NewDiffer builds a Differ with the given options applied on top of the
+// defaults.
+func NewDiffer(opts ...func(*Differ) error) (*Differ, error) {
+	d := &Differ{
+		tagName: DefaultTagName,
+	}
+
+	for _, opt := range opts {
+		if err := opt(d); err != nil {
+			return nil, err
+		}
+	}
+
+	return d, nil
+}
+
+// DefaultDiffer is the Differ used by the package-level Diff function.
+var DefaultDiffer, _ = NewDiffer()
+
+// Diff compares a and b, which must be of the same type, and returns a
+// Changelog of the differences found. It is a thin wrapper around
+// DefaultDiffer.Diff.
+func Diff(a, b interface{}) (Changelog, error) {
+	return DefaultDiffer.Diff(a, b)
+}
+
+// Diff compares a and b, which must be of the same type, and returns a
+// Changelog of the differences found.
+func (d *Differ) Diff(a, b interface{}) (Changelog, error) {
+	cl := Changelog{}
+
+	if err := d.diff(nil, &cl, reflect.ValueOf(a), reflect.ValueOf(b)); err != nil {
+		return nil, err
+	}
+
+	return cl, nil
+}
+
+// diff dispatches to the appropriate comparison strategy for the kind of
+// value being compared, appending any Changes found to cl.
+func (d *Differ) diff(path []string, cl *Changelog, a, b reflect.Value) error {
+	if !a.IsValid() || !b.IsValid() {
+		return nil
+	}
+
+	if d.convertCompatibleTypes && a.Type() != b.Type() && b.Type().ConvertibleTo(a.Type()) {
+		b = b.Convert(a.Type())
+	}
+
+	if cmp, ok := d.comparators[a.Type()]; ok {
+		return d.diffWithComparator(path, cl, a, b, cmp)
+	}
+
+	switch a.Kind() {
+	case reflect.Ptr:
+		return d.diffPtr(path, cl, a, b)
+	case reflect.Struct:
+		return d.diffStruct(path, cl, a, b)
+	case reflect.Slice, reflect.Array:
+		return d.diffSlice(path, cl, a, b)
+	case reflect.Map:
+		return d.diffMap(path, cl, a, b)
+	default:
+		return d.diffScalar(path, cl, a, b)
+	}
+}
+
+// diffWithComparator reports a single UPDATE when cmp finds a and b unequal,
+// overriding the kind-based walk entirely for a's type.
+func (d *Differ) diffWithComparator(path []string, cl *Changelog, a, b reflect.Value, cmp Comparator) error {
+	equal, err := cmp(a, b)
+	if err != nil {
+		return err
+	}
+	if !equal {
+		*cl = append(*cl, Change{Type: UPDATE, Path: clonePath(path), From: a.Interface(), To: b.Interface()})
+	}
+	return nil
+}
+
+// diffPtr compares two pointers. A nil-to-value transition is reported with
+// the raw pointer value rather than recursing, mirroring how the zero value
+// of the pointed-to type would otherwise be indistinguishable from "unset".
+func (d *Differ) diffPtr(path []string, cl *Changelog, a, b reflect.Value) error {
+	aNil, bNil := a.IsNil(), b.IsNil()
+
+	switch {
+	case aNil && bNil:
+		return nil
+	case aNil && !bNil:
+		*cl = append(*cl, Change{Type: UPDATE, Path: clonePath(path), From: nil, To: b.Interface()})
+		return nil
+	case !aNil && bNil:
+		*cl = append(*cl, Change{Type: UPDATE, Path: clonePath(path), From: a.Interface(), To: nil})
+		return nil
+	default:
+		return d.diff(path, cl, a.Elem(), b.Elem())
+	}
+}
+
+// diffStruct walks the exported fields of a struct, naming each by its diff
+// tag (or its field name when untagged) and skipping fields tagged "-".
+func (d *Differ) diffStruct(path []string, cl *Changelog, a, b reflect.Value) error {
+	t := a.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		opts := parseTag(field.Tag.Get(d.tagName), field.Name)
+		if opts.Ignore {
+			continue
+		}
+
+		if err := d.diff(appendPath(path, opts.Name), cl, a.Field(i), b.Field(i)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// diffMap treats maps as atomic values: a single UPDATE is reported when the
+// two maps are not deeply equal.
+func (d *Differ) diffMap(path []string, cl *Changelog, a, b reflect.Value) error {
+	if reflect.DeepEqual(a.Interface(), b.Interface()) {
+		return nil
+	}
+
+	*cl = append(*cl, Change{Type: UPDATE, Path: clonePath(path), From: a.Interface(), To: b.Interface()})
+	return nil
+}
+
+// diffScalar reports a single UPDATE when a and b are not deeply equal. For
+// floats with a WithFloatTolerance set, a and b are instead considered
+// equal if they're within epsilon of each other.
+func (d *Differ) diffScalar(path []string, cl *Changelog, a, b reflect.Value) error {
+	if d.floatTolerance > 0 && (a.Kind() == reflect.Float32 || a.Kind() == reflect.Float64) {
+		delta := a.Float() - b.Float()
+		if delta < 0 {
+			delta = -delta
+		}
+		if delta <= d.floatTolerance {
+			return nil
+		}
+	} else if reflect.DeepEqual(a.Interface(), b.Interface()) {
+		return nil
+	}
+
+	*cl = append(*cl, Change{Type: UPDATE, Path: clonePath(path), From: a.Interface(), To: b.Interface()})
+	return nil
+}
+
+// appendPath returns a new path with seg appended, leaving path untouched.
+func appendPath(path []string, seg string) []string {
+	np := make([]string, len(path)+1)
+	copy(np, path)
+	np[len(path)] = seg
+	return np
+}
+
+// clonePath returns a copy of path so callers can't mutate a Change's Path
+// through a slice still held elsewhere.
+func clonePath(path []string) []string {
+	cp := make([]string, len(path))
+	copy(cp, path)
+	return cp
+}
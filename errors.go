@@ -0,0 +1,16 @@
+package diff
+
+import "errors"
+
+// Sentinel errors returned (wrapped) by Patch and Revert when a Change
+// cannot be located or applied against the destination value.
+var (
+	// ErrPathNotFound is returned when a Change's Path does not resolve to
+	// a field, slice element, or map key on the destination value.
+	ErrPathNotFound = errors.New("diff: path not found")
+
+	// ErrTypeMismatch is returned when a Change's Path resolves to a value
+	// that cannot hold the kind of change being applied (e.g. indexing
+	// into a scalar, or assigning an incompatible type).
+	ErrTypeMismatch = errors.New("diff: type mismatch")
+)
@@ -0,0 +1,223 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// jsonPatchOp is a single operation in an RFC 6902 JSON Patch document.
+//
+// From is not part of the "add", "remove", or "replace" operations defined
+// by the spec (it's only standard for "move"/"copy", which this package
+// never emits). Here it is a documented extension: it carries Change.From
+// so that UnmarshalJSONPatch can reconstruct a Changelog losslessly,
+// including the prior value of a remove or replace. Consumers that only
+// care about spec-compliant application of the patch can ignore it.
+type jsonPatchOp struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+	From  interface{} `json:"from,omitempty"`
+}
+
+// MarshalJSONPatch renders cl as an RFC 6902 JSON Patch document.
+//
+// Path segments are taken directly from each Change's Path, RFC 6901
+// escaped, and joined with "/". This is spec-compliant for struct fields
+// and plain, index-addressed slice entries. Identifier-keyed slice entries
+// (see the `identifier` struct tag) are emitted as their identifier value
+// rather than a numeric array index, which is a documented extension of
+// the spec purely for round-tripping through Go; a strict RFC 6902
+// consumer will not be able to apply such a patch to a JSON array. Use
+// MarshalJSONPatchWithReference to resolve identifiers to real array
+// indices against a reference document instead.
+func MarshalJSONPatch(cl Changelog) ([]byte, error) {
+	ops := make([]jsonPatchOp, len(cl))
+	for i, c := range cl {
+		ops[i] = jsonPatchOp{
+			Op:    jsonPatchOpName(c.Type),
+			Path:  JSONPointer(c.Path),
+			Value: c.To,
+			From:  c.From,
+		}
+	}
+	return json.Marshal(ops)
+}
+
+// MarshalJSONPatchWithReference renders cl as an RFC 6902 JSON Patch
+// document, resolving any identifier-keyed slice path segment to the
+// numeric array index it occupies, instead of emitting the identifier
+// literally. from and to are the pre- and post-image values the Changelog
+// was produced from (i.e. Diff's a and b), and must be of the same type.
+// Both are needed because a single reference cannot resolve every op: a
+// CREATE's identifier only exists in the post-image and a DELETE's only
+// in the pre-image, which is the ordinary shape of a Changelog whenever a
+// Diff both adds and removes elements of the same identifiable slice.
+// UPDATE and CREATE paths are resolved against to, DELETE paths against
+// from. Use this when the resulting patch needs to be spec-compliant,
+// e.g. for applying to a plain JSON array with a standard JSON Patch
+// library.
+func MarshalJSONPatchWithReference(cl Changelog, from, to interface{}) ([]byte, error) {
+	return DefaultDiffer.MarshalJSONPatchWithReference(cl, from, to)
+}
+
+// MarshalJSONPatchWithReference renders cl using d's tag name. See the
+// package-level MarshalJSONPatchWithReference for details.
+func (d *Differ) MarshalJSONPatchWithReference(cl Changelog, from, to interface{}) ([]byte, error) {
+	fromVal, toVal := reflect.ValueOf(from), reflect.ValueOf(to)
+
+	ops := make([]jsonPatchOp, len(cl))
+	for i, c := range cl {
+		ref := toVal
+		if c.Type == DELETE {
+			ref = fromVal
+		}
+
+		path, err := resolveIndices(ref, d.tagName, c.Path)
+		if err != nil {
+			return nil, fmt.Errorf("diff: resolving path %v against reference: %w", c.Path, err)
+		}
+		ops[i] = jsonPatchOp{
+			Op:    jsonPatchOpName(c.Type),
+			Path:  JSONPointer(path),
+			Value: c.To,
+			From:  c.From,
+		}
+	}
+	return json.Marshal(ops)
+}
+
+// UnmarshalJSONPatch parses an RFC 6902 JSON Patch document into a
+// Changelog. The From extension described on jsonPatchOp is read back into
+// Change.From when present; otherwise From is left nil, as it would be for
+// a CREATE produced by Diff.
+func UnmarshalJSONPatch(data []byte) (Changelog, error) {
+	var ops []jsonPatchOp
+	if err := json.Unmarshal(data, &ops); err != nil {
+		return nil, err
+	}
+
+	cl := make(Changelog, len(ops))
+	for i, op := range ops {
+		typ, err := changeType(op.Op)
+		if err != nil {
+			return nil, err
+		}
+
+		path, err := parseJSONPointer(op.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		cl[i] = Change{Type: typ, Path: path, From: op.From, To: op.Value}
+	}
+
+	return cl, nil
+}
+
+func jsonPatchOpName(t string) string {
+	switch t {
+	case CREATE:
+		return "add"
+	case DELETE:
+		return "remove"
+	default:
+		return "replace"
+	}
+}
+
+func changeType(op string) (string, error) {
+	switch op {
+	case "add":
+		return CREATE, nil
+	case "remove":
+		return DELETE, nil
+	case "replace":
+		return UPDATE, nil
+	default:
+		return "", fmt.Errorf("diff: unsupported JSON Patch op %q", op)
+	}
+}
+
+// JSONPointer renders path as an RFC 6901 JSON Pointer.
+func JSONPointer(path []string) string {
+	var b strings.Builder
+	for _, seg := range path {
+		b.WriteByte('/')
+		b.WriteString(escapePointerSegment(seg))
+	}
+	return b.String()
+}
+
+func parseJSONPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("diff: invalid JSON Pointer %q", pointer)
+	}
+
+	segs := strings.Split(pointer[1:], "/")
+	path := make([]string, len(segs))
+	for i, s := range segs {
+		path[i] = unescapePointerSegment(s)
+	}
+	return path, nil
+}
+
+func escapePointerSegment(seg string) string {
+	seg = strings.ReplaceAll(seg, "~", "~0")
+	seg = strings.ReplaceAll(seg, "/", "~1")
+	return seg
+}
+
+func unescapePointerSegment(seg string) string {
+	seg = strings.ReplaceAll(seg, "~1", "/")
+	seg = strings.ReplaceAll(seg, "~0", "~")
+	return seg
+}
+
+// resolveIndices walks ref along path, rewriting any segment that addresses
+// an identifier-keyed slice element into the numeric index it occupies.
+// Struct field segments and already-numeric slice segments are returned
+// unchanged.
+func resolveIndices(ref reflect.Value, tagName string, path []string) ([]string, error) {
+	out := make([]string, len(path))
+	cur := ref
+
+	for i, seg := range path {
+		for cur.Kind() == reflect.Ptr {
+			if cur.IsNil() {
+				return nil, fmt.Errorf("%w: nil pointer at %q", ErrPathNotFound, seg)
+			}
+			cur = cur.Elem()
+		}
+
+		switch cur.Kind() {
+		case reflect.Struct:
+			idx := fieldIndexByTag(cur.Type(), tagName, seg)
+			if idx < 0 {
+				return nil, fmt.Errorf("%w: no field tagged %q", ErrPathNotFound, seg)
+			}
+			out[i] = seg
+			cur = cur.Field(idx)
+		case reflect.Slice, reflect.Array:
+			idx, found, err := locateSliceIndex(cur, tagName, seg)
+			if err != nil {
+				return nil, err
+			}
+			if !found {
+				return nil, fmt.Errorf("%w: no element %q", ErrPathNotFound, seg)
+			}
+			out[i] = strconv.Itoa(idx)
+			cur = cur.Index(idx)
+		default:
+			return nil, fmt.Errorf("%w: cannot resolve %q under %s", ErrTypeMismatch, seg, cur.Kind())
+		}
+	}
+
+	return out, nil
+}
@@ -0,0 +1,97 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONPointer(t *testing.T) {
+	cases := []struct {
+		Name string
+		Path []string
+		Want string
+	}{
+		{"empty", nil, ""},
+		{"single", []string{"name"}, "/name"},
+		{"nested", []string{"values", "1"}, "/values/1"},
+		{"escapes-tilde-and-slash", []string{"a/b", "c~d"}, "/a~1b/c~0d"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			assert.Equal(t, tc.Want, JSONPointer(tc.Path))
+
+			path, err := parseJSONPointer(tc.Want)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.Path, path)
+		})
+	}
+}
+
+func TestMarshalUnmarshalJSONPatch(t *testing.T) {
+	cl := Changelog{
+		{Type: CREATE, Path: []string{"identifiables", "two"}, To: tistruct{"two", 2}},
+		{Type: DELETE, Path: []string{"values", "1"}, From: "two"},
+		{Type: UPDATE, Path: []string{"name"}, From: "one", To: "two"},
+	}
+
+	data, err := MarshalJSONPatch(cl)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"op":"add","path":"/identifiables/two","value":{"Name":"two","Value":2}},
+		{"op":"remove","path":"/values/1","from":"two"},
+		{"op":"replace","path":"/name","from":"one","value":"two"}
+	]`, string(data))
+
+	got, err := UnmarshalJSONPatch(data)
+	assert.NoError(t, err)
+	assert.Equal(t, CREATE, got[0].Type)
+	assert.Equal(t, Path{"identifiables", "two"}, got[0].Path)
+	assert.Equal(t, DELETE, got[1].Type)
+	assert.Equal(t, "two", got[1].From)
+	assert.Equal(t, UPDATE, got[2].Type)
+	assert.Equal(t, "one", got[2].From)
+	assert.Equal(t, "two", got[2].To)
+}
+
+func TestMarshalJSONPatchWithReference(t *testing.T) {
+	from := tstruct{Identifiables: []tistruct{{"one", 1}, {"two", 2}}}
+	to := tstruct{Identifiables: []tistruct{{"one", 1}}}
+	cl := Changelog{
+		{Type: DELETE, Path: []string{"identifiables", "two"}, From: tistruct{"two", 2}},
+	}
+
+	data, err := MarshalJSONPatchWithReference(cl, from, to)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[{"op":"remove","path":"/identifiables/1","from":{"Name":"two","Value":2}}]`, string(data))
+}
+
+func TestMarshalJSONPatchWithReferenceUnknownIdentifier(t *testing.T) {
+	from := tstruct{Identifiables: []tistruct{{"one", 1}}}
+	cl := Changelog{
+		{Type: DELETE, Path: []string{"identifiables", "missing"}, From: tistruct{"missing", 0}},
+	}
+
+	_, err := MarshalJSONPatchWithReference(cl, from, from)
+	assert.ErrorIs(t, err, ErrPathNotFound)
+}
+
+func TestMarshalJSONPatchWithReferenceMixedCreateAndDelete(t *testing.T) {
+	from := tstruct{Identifiables: []tistruct{{"one", 1}, {"three", 3}}}
+	to := tstruct{Identifiables: []tistruct{{"one", 1}, {"two", 2}}}
+
+	cl, err := Diff(from, to)
+	assert.NoError(t, err)
+	assert.Equal(t, Changelog{
+		{Type: DELETE, Path: Path{"identifiables", "three"}, From: tistruct{"three", 3}},
+		{Type: CREATE, Path: Path{"identifiables", "two"}, To: tistruct{"two", 2}},
+	}, cl)
+
+	data, err := MarshalJSONPatchWithReference(cl, from, to)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"op":"remove","path":"/identifiables/1","from":{"Name":"three","Value":3}},
+		{"op":"add","path":"/identifiables/1","value":{"Name":"two","Value":2}}
+	]`, string(data))
+}
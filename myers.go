@@ -0,0 +1,133 @@
+package diff
+
+import (
+	"reflect"
+	"strconv"
+)
+
+// diffMyersSlice emits the shortest edit script between a and b, computed
+// with Myers' O(ND) algorithm, as CREATE/DELETE changes. Delete indices
+// are in A's coordinate system and insert indices are in B's, matching
+// what a JSON Patch consumer expects to apply against the original and
+// resulting documents respectively.
+func (d *Differ) diffMyersSlice(path []string, cl *Changelog, a, b reflect.Value) error {
+	equal := func(i, j int) bool {
+		return reflect.DeepEqual(a.Index(i).Interface(), b.Index(j).Interface())
+	}
+
+	for _, s := range myersEditScript(a.Len(), b.Len(), equal) {
+		switch s.op {
+		case myersDelete:
+			*cl = append(*cl, Change{Type: DELETE, Path: appendPath(path, strconv.Itoa(s.aIdx)), From: a.Index(s.aIdx).Interface()})
+		case myersInsert:
+			*cl = append(*cl, Change{Type: CREATE, Path: appendPath(path, strconv.Itoa(s.bIdx)), To: b.Index(s.bIdx).Interface()})
+		}
+	}
+
+	return nil
+}
+
+type myersOp int
+
+const (
+	myersKeep myersOp = iota
+	myersDelete
+	myersInsert
+)
+
+type myersStep struct {
+	op   myersOp
+	aIdx int
+	bIdx int
+}
+
+// myersEditScript returns the shortest sequence of keep/delete/insert
+// steps that turns a sequence of length n into one of length m, given a
+// predicate reporting whether a[i] and b[j] are equal. It implements
+// Myers' 1986 "An O(ND) Difference Algorithm and Its Variations": a
+// forward search over the edit graph finds the minimal edit distance,
+// then a backtrack over the per-depth search state reconstructs the path.
+func myersEditScript(n, m int, equal func(i, j int) bool) []myersStep {
+	max := n + m
+	if max == 0 {
+		return nil
+	}
+
+	offset := max
+	v := make([]int, 2*max+1)
+	trace := make([][]int, 0, max+1)
+
+	for d := 0; d <= max; d++ {
+		snapshot := make([]int, len(v))
+		copy(snapshot, v)
+		trace = append(trace, snapshot)
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+
+			y := x - k
+			for x < n && y < m && equal(x, y) {
+				x++
+				y++
+			}
+
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return myersBacktrack(trace, n, m, offset)
+			}
+		}
+	}
+
+	return nil
+}
+
+// myersBacktrack walks trace from the end point (n, m) back to the origin,
+// reading off a keep for every diagonal move and a delete/insert for
+// every horizontal/vertical one, then reverses the result into forward
+// order.
+func myersBacktrack(trace [][]int, n, m, offset int) []myersStep {
+	var steps []myersStep
+	x, y := n, m
+
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+
+		var prevK int
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+
+		prevX := v[offset+prevK]
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			steps = append(steps, myersStep{op: myersKeep, aIdx: x - 1, bIdx: y - 1})
+			x--
+			y--
+		}
+
+		if d > 0 {
+			if x == prevX {
+				steps = append(steps, myersStep{op: myersInsert, bIdx: prevY})
+			} else {
+				steps = append(steps, myersStep{op: myersDelete, aIdx: prevX})
+			}
+		}
+
+		x, y = prevX, prevY
+	}
+
+	for i, j := 0, len(steps)-1; i < j; i, j = i+1, j-1 {
+		steps[i], steps[j] = steps[j], steps[i]
+	}
+
+	return steps
+}
@@ -0,0 +1,77 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffWithSliceOrderingMyers(t *testing.T) {
+	d, err := NewDiffer(WithSliceOrdering(SliceMyers))
+	assert.NoError(t, err)
+
+	cases := []struct {
+		Name      string
+		A, B      interface{}
+		Changelog Changelog
+	}{
+		{
+			"int-slice-insert", []int{1, 2, 3}, []int{1, 2, 3, 4},
+			Changelog{
+				Change{Type: CREATE, Path: []string{"3"}, To: 4},
+			},
+		},
+		{
+			"int-slice-delete", []int{1, 2, 3}, []int{1, 3},
+			Changelog{
+				Change{Type: DELETE, Path: []string{"1"}, From: 2},
+			},
+		},
+		{
+			"int-slice-insert-delete", []int{1, 2, 3}, []int{1, 3, 4},
+			Changelog{
+				Change{Type: DELETE, Path: []string{"1"}, From: 2},
+				Change{Type: CREATE, Path: []string{"2"}, To: 4},
+			},
+		},
+		{
+			"insert-at-front", []int{1, 2, 3}, []int{0, 1, 2, 3},
+			Changelog{
+				Change{Type: CREATE, Path: []string{"0"}, To: 0},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			cl, err := d.Diff(tc.A, tc.B)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.Changelog, cl)
+		})
+	}
+}
+
+func TestDiffWithSliceOrderingMyersLargeInsertAtFront(t *testing.T) {
+	d, err := NewDiffer(WithSliceOrdering(SliceMyers))
+	assert.NoError(t, err)
+
+	a := make([]int, 1000)
+	for i := range a {
+		a[i] = i
+	}
+	b := append([]int{-1}, a...)
+
+	cl, err := d.Diff(a, b)
+	assert.NoError(t, err)
+	assert.Len(t, cl, 1)
+	assert.Equal(t, Change{Type: CREATE, Path: []string{"0"}, To: -1}, cl[0])
+}
+
+func TestDiffDefaultSliceOrderingUnchanged(t *testing.T) {
+	cl, err := Diff([]int{1, 2, 3}, []int{1, 3, 4})
+	assert.NoError(t, err)
+	assert.Equal(t, Changelog{
+		Change{Type: DELETE, Path: []string{"1"}, From: 2},
+		Change{Type: CREATE, Path: []string{"2"}, To: 4},
+	}, cl)
+}
@@ -0,0 +1,103 @@
+package diff
+
+import (
+	"reflect"
+	"time"
+)
+
+// Comparator decides whether a and b, both of the same type, are equal.
+// It overrides the normal kind-based walk for that type entirely: diff
+// will report a single UPDATE (or nothing) rather than recursing into a's
+// fields or elements.
+type Comparator func(a, b reflect.Value) (equal bool, err error)
+
+// WithComparator registers cmp as the equality check for values of type t,
+// in place of the default reflection walk. This is the escape hatch for
+// opaque or semantically-compared types a fixed walker can't handle
+// correctly, such as decimal.Decimal or big.Int.
+func WithComparator(t reflect.Type, cmp Comparator) func(*Differ) error {
+	return func(d *Differ) error {
+		if d.comparators == nil {
+			d.comparators = map[reflect.Type]Comparator{}
+		}
+		d.comparators[t] = cmp
+		return nil
+	}
+}
+
+// WithTimeComparator registers equal as the equality check for time.Time,
+// a shorthand for WithComparator(reflect.TypeOf(time.Time{}), ...) that
+// saves callers from unwrapping the reflect.Values themselves. Use it to
+// compare times by semantic equality (time.Time.Equal) rather than by the
+// struct's internal representation, which a DeepEqual-based walk would
+// otherwise treat as different across time zones or monotonic readings.
+func WithTimeComparator(equal func(a, b time.Time) bool) func(*Differ) error {
+	return WithComparator(reflect.TypeOf(time.Time{}), func(a, b reflect.Value) (bool, error) {
+		return equal(a.Interface().(time.Time), b.Interface().(time.Time)), nil
+	})
+}
+
+// WithFloatTolerance treats two float32 or float64 values as equal when
+// they differ by no more than epsilon, instead of requiring an exact
+// match.
+func WithFloatTolerance(epsilon float64) func(*Differ) error {
+	return func(d *Differ) error {
+		d.floatTolerance = epsilon
+		return nil
+	}
+}
+
+// WithTagName overrides the struct tag key Differ reads to configure
+// field names, identifiers, and ignored fields. The default is
+// DefaultTagName ("diff").
+func WithTagName(name string) func(*Differ) error {
+	return func(d *Differ) error {
+		d.tagName = name
+		return nil
+	}
+}
+
+// WithConvertCompatibleTypes allows comparing two values whose types
+// differ but share an underlying type, such as a named `type MyString
+// string` against a plain string with the same contents, by converting b
+// to a's type before comparing. Without it, such a comparison falls
+// through to the default kind-based walk using b's original type, which
+// for structs and slices still works but for direct scalar comparisons at
+// the root can report a spurious difference.
+func WithConvertCompatibleTypes(convert bool) func(*Differ) error {
+	return func(d *Differ) error {
+		d.convertCompatibleTypes = convert
+		return nil
+	}
+}
+
+// SliceOrdering selects the algorithm used to match elements of two slices
+// that are not keyed by an `identifier` tagged field.
+type SliceOrdering int
+
+const (
+	// SliceKeyed matches elements by their own value regardless of
+	// position, reporting an unmatched element of A as a DELETE at its
+	// original index and an unmatched element of B as a CREATE at its new
+	// index. This is the default.
+	SliceKeyed SliceOrdering = iota
+
+	// SliceMyers computes the shortest edit script between the two
+	// slices with Myers' O(ND) algorithm, emitting it as CREATE/DELETE
+	// changes. Unlike SliceKeyed it accounts for element order, so an
+	// element that only moved is reported as a DELETE and a CREATE rather
+	// than as no change at all. It only
+	// applies to slices whose element type is comparable; slices of
+	// uncomparable elements (e.g. of slices or maps) always use
+	// SliceKeyed.
+	SliceMyers
+)
+
+// WithSliceOrdering sets the algorithm used to diff slices whose element
+// type has no `identifier` tagged field.
+func WithSliceOrdering(o SliceOrdering) func(*Differ) error {
+	return func(d *Differ) error {
+		d.sliceOrdering = o
+		return nil
+	}
+}
@@ -0,0 +1,96 @@
+package diff
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithFloatTolerance(t *testing.T) {
+	d, err := NewDiffer(WithFloatTolerance(0.01))
+	assert.NoError(t, err)
+
+	cl, err := d.Diff(1.0, 1.005)
+	assert.NoError(t, err)
+	assert.Empty(t, cl)
+
+	cl, err = d.Diff(1.0, 1.5)
+	assert.NoError(t, err)
+	assert.Equal(t, Changelog{{Type: UPDATE, Path: Path{}, From: 1.0, To: 1.5}}, cl)
+}
+
+func TestWithTimeComparator(t *testing.T) {
+	d, err := NewDiffer(WithTimeComparator(time.Time.Equal))
+	assert.NoError(t, err)
+
+	utc := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	sameInstant := utc.In(time.FixedZone("UTC+1", 3600))
+
+	cl, err := d.Diff(utc, sameInstant)
+	assert.NoError(t, err)
+	assert.Empty(t, cl)
+
+	cl, err = d.Diff(utc, utc.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Len(t, cl, 1)
+	assert.Equal(t, UPDATE, cl[0].Type)
+}
+
+func TestWithComparator(t *testing.T) {
+	type opaque struct{ n int }
+
+	d, err := NewDiffer(WithComparator(reflect.TypeOf(opaque{}), func(a, b reflect.Value) (bool, error) {
+		return a.Interface().(opaque).n%10 == b.Interface().(opaque).n%10, nil
+	}))
+	assert.NoError(t, err)
+
+	cl, err := d.Diff(opaque{n: 3}, opaque{n: 13})
+	assert.NoError(t, err)
+	assert.Empty(t, cl)
+
+	cl, err = d.Diff(opaque{n: 3}, opaque{n: 4})
+	assert.NoError(t, err)
+	assert.Len(t, cl, 1)
+}
+
+func TestWithTagName(t *testing.T) {
+	type custom struct {
+		Name string `json:"name"`
+	}
+
+	d, err := NewDiffer(WithTagName("json"))
+	assert.NoError(t, err)
+
+	cl, err := d.Diff(custom{Name: "one"}, custom{Name: "two"})
+	assert.NoError(t, err)
+	assert.Equal(t, Changelog{{Type: UPDATE, Path: Path{"name"}, From: "one", To: "two"}}, cl)
+}
+
+func TestWithConvertCompatibleTypes(t *testing.T) {
+	type myString string
+
+	d, err := NewDiffer(WithConvertCompatibleTypes(true))
+	assert.NoError(t, err)
+
+	cl, err := d.Diff(myString("hello"), "hello")
+	assert.NoError(t, err)
+	assert.Empty(t, cl)
+
+	cl, err = d.Diff(myString("hello"), "world")
+	assert.NoError(t, err)
+	assert.Len(t, cl, 1)
+}
+
+func TestDiffIsThinWrapperOverDefaultDiffer(t *testing.T) {
+	a, b := tstruct{Name: "one"}, tstruct{Name: "two"}
+
+	viaPackage, err := Diff(a, b)
+	assert.NoError(t, err)
+
+	viaDefaultDiffer, err := DefaultDiffer.Diff(a, b)
+	assert.NoError(t, err)
+
+	assert.Equal(t, viaDefaultDiffer, viaPackage)
+}
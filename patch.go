@@ -0,0 +1,407 @@
+package diff
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// PatchLog status values.
+const (
+	PatchApplied = "applied"
+	PatchSkipped = "skipped"
+	PatchErrored = "errored"
+)
+
+// PatchEntry records the outcome of applying a single Change.
+type PatchEntry struct {
+	Change Change
+	Status string
+	Error  error
+}
+
+// PatchLog is the per-change result of a Patch or Revert call, in the same
+// order as the Changelog that produced it.
+type PatchLog []PatchEntry
+
+// Patch applies cl to dst, which must be a non-nil pointer to a value of
+// the same type the Changelog was produced from, reconstructing the "to"
+// side of the diff. A failure to apply one Change (missing path, type
+// mismatch) is recorded in the returned PatchLog rather than aborting the
+// remaining changes; Patch only returns a non-nil error for a malformed
+// call (e.g. dst isn't a pointer).
+func Patch(cl Changelog, dst interface{}) (PatchLog, error) {
+	return DefaultDiffer.Patch(cl, dst)
+}
+
+// Patch applies cl to dst using d's tag name. See the package-level Patch
+// for details.
+func (d *Differ) Patch(cl Changelog, dst interface{}) (PatchLog, error) {
+	return d.applyChangelog(cl, dst, false)
+}
+
+// Revert applies the inverse of cl to dst, reconstructing the "from" side
+// of the diff. See Patch for error and PatchLog semantics.
+func Revert(cl Changelog, dst interface{}) (PatchLog, error) {
+	return DefaultDiffer.Revert(cl, dst)
+}
+
+// Revert applies the inverse of cl to dst using d's tag name. See the
+// package-level Revert for details.
+func (d *Differ) Revert(cl Changelog, dst interface{}) (PatchLog, error) {
+	return d.applyChangelog(cl, dst, true)
+}
+
+func (d *Differ) applyChangelog(cl Changelog, dst interface{}, revert bool) (PatchLog, error) {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return nil, fmt.Errorf("diff: Patch/Revert target must be a non-nil pointer, got %T", dst)
+	}
+	root := rv.Elem()
+
+	applied := make(Changelog, len(cl))
+	for i, c := range cl {
+		if revert {
+			c = invert(c)
+		}
+		applied[i] = c
+	}
+
+	pl := make(PatchLog, len(cl))
+	for _, i := range applyOrder(applied) {
+		status, err := d.applyChange(root, applied[i])
+		entry := PatchEntry{Change: cl[i], Status: status, Error: err}
+		if err != nil {
+			entry.Status = PatchErrored
+		}
+		pl[i] = entry
+	}
+
+	return pl, nil
+}
+
+// applyOrder returns the indices of cl in the order they should be applied:
+// non-indexed changes (struct field updates, identifier-keyed slice
+// entries) first in their original order, then indexed slice deletions in
+// descending index order, then indexed slice insertions in ascending index
+// order. Applying deletions highest-index-first keeps the indices of
+// not-yet-processed deletions valid as the slice shrinks, and likewise for
+// insertions as it grows.
+func applyOrder(cl Changelog) []int {
+	var rest, deletes, creates []int
+
+	for i, c := range cl {
+		_, ok := indexedSliceOp(c)
+		switch {
+		case !ok:
+			rest = append(rest, i)
+		case c.Type == DELETE:
+			deletes = append(deletes, i)
+		default:
+			creates = append(creates, i)
+		}
+	}
+
+	sort.SliceStable(deletes, func(i, j int) bool {
+		return sliceOpIndex(cl[deletes[i]]) > sliceOpIndex(cl[deletes[j]])
+	})
+	sort.SliceStable(creates, func(i, j int) bool {
+		return sliceOpIndex(cl[creates[i]]) < sliceOpIndex(cl[creates[j]])
+	})
+
+	order := make([]int, 0, len(cl))
+	order = append(order, rest...)
+	order = append(order, deletes...)
+	order = append(order, creates...)
+	return order
+}
+
+// indexedSliceOp reports whether c is a CREATE/DELETE whose last path
+// segment is a plain numeric slice index, along with that index.
+func indexedSliceOp(c Change) (int, bool) {
+	if c.Type != CREATE && c.Type != DELETE {
+		return 0, false
+	}
+	if len(c.Path) == 0 {
+		return 0, false
+	}
+	idx, err := strconv.Atoi(c.Path[len(c.Path)-1])
+	if err != nil {
+		return 0, false
+	}
+	return idx, true
+}
+
+func sliceOpIndex(c Change) int {
+	idx, _ := indexedSliceOp(c)
+	return idx
+}
+
+// invert swaps a Change so that applying it undoes the original: CREATE
+// becomes DELETE (and vice versa), From and To swap, and UPDATE keeps its
+// type with From/To swapped.
+func invert(c Change) Change {
+	ic := c
+	ic.From, ic.To = c.To, c.From
+
+	switch c.Type {
+	case CREATE:
+		ic.Type = DELETE
+	case DELETE:
+		ic.Type = CREATE
+	}
+
+	return ic
+}
+
+// applyChange navigates root to the container addressed by all but the
+// last segment of c.Path, then applies c against that container's final
+// segment.
+func (d *Differ) applyChange(root reflect.Value, c Change) (string, error) {
+	if len(c.Path) == 0 {
+		return "", fmt.Errorf("diff: %w: empty path", ErrPathNotFound)
+	}
+
+	parent := root
+	for _, seg := range c.Path[:len(c.Path)-1] {
+		next, err := d.descend(parent, seg)
+		if err != nil {
+			return "", err
+		}
+		parent = next
+	}
+
+	return d.applyLeaf(parent, c.Path[len(c.Path)-1], c)
+}
+
+// descend steps from cur into the child addressed by seg: a struct field
+// by its diff tag, or a slice element by identifier (if tagged) or index.
+// A nil pointer encountered along the way is allocated, mirroring how Diff
+// reports a nil-to-value transition against the zero value.
+func (d *Differ) descend(cur reflect.Value, seg string) (reflect.Value, error) {
+	for cur.Kind() == reflect.Ptr {
+		if cur.IsNil() {
+			if !cur.CanSet() {
+				return reflect.Value{}, fmt.Errorf("diff: %w: nil pointer at %q", ErrTypeMismatch, seg)
+			}
+			cur.Set(reflect.New(cur.Type().Elem()))
+		}
+		cur = cur.Elem()
+	}
+
+	switch cur.Kind() {
+	case reflect.Struct:
+		idx := fieldIndexByTag(cur.Type(), d.tagName, seg)
+		if idx < 0 {
+			return reflect.Value{}, fmt.Errorf("diff: %w: no field tagged %q", ErrPathNotFound, seg)
+		}
+		return cur.Field(idx), nil
+	case reflect.Slice, reflect.Array:
+		idx, found, err := locateSliceIndex(cur, d.tagName, seg)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if !found {
+			return reflect.Value{}, fmt.Errorf("diff: %w: no element %q", ErrPathNotFound, seg)
+		}
+		return cur.Index(idx), nil
+	default:
+		return reflect.Value{}, fmt.Errorf("diff: %w: cannot descend into %s at %q", ErrTypeMismatch, cur.Kind(), seg)
+	}
+}
+
+// applyLeaf applies c to the struct field or slice element of parent
+// addressed by the final path segment seg. Any other parent kind, arrays
+// included, is reported as ErrTypeMismatch.
+func (d *Differ) applyLeaf(parent reflect.Value, seg string, c Change) (string, error) {
+	switch parent.Kind() {
+	case reflect.Struct:
+		idx := fieldIndexByTag(parent.Type(), d.tagName, seg)
+		if idx < 0 {
+			return "", fmt.Errorf("diff: %w: no field tagged %q", ErrPathNotFound, seg)
+		}
+		return applyField(parent.Field(idx), c)
+	case reflect.Slice:
+		return applySliceChange(parent, d.tagName, seg, c)
+	default:
+		return "", fmt.Errorf("diff: %w: cannot apply change under %s", ErrTypeMismatch, parent.Kind())
+	}
+}
+
+// applyField assigns c's target value to fv (a struct field), honouring
+// the nil-pointer convention used by diffPtr. It reports PatchSkipped
+// without mutating fv if fv already holds the target value.
+func applyField(fv reflect.Value, c Change) (string, error) {
+	target := c.To
+	if c.Type == DELETE {
+		target = nil
+	}
+
+	if fv.Kind() == reflect.Ptr {
+		return applyPtrField(fv, target)
+	}
+
+	if target == nil {
+		if isZero(fv) {
+			return PatchSkipped, nil
+		}
+		fv.Set(reflect.Zero(fv.Type()))
+		return PatchApplied, nil
+	}
+
+	tv := reflect.ValueOf(target)
+	if !tv.Type().ConvertibleTo(fv.Type()) {
+		return "", fmt.Errorf("diff: %w: cannot assign %s to %s", ErrTypeMismatch, tv.Type(), fv.Type())
+	}
+	tv = tv.Convert(fv.Type())
+
+	if reflect.DeepEqual(fv.Interface(), tv.Interface()) {
+		return PatchSkipped, nil
+	}
+	fv.Set(tv)
+	return PatchApplied, nil
+}
+
+// applyPtrField assigns target to a pointer-typed field. target may be the
+// raw pointer itself (the nil<->value transition, as reported by diffPtr)
+// or a dereferenced scalar (a change between two non-nil pointers), which
+// is assigned through to the pointee.
+func applyPtrField(fv reflect.Value, target interface{}) (string, error) {
+	if target == nil {
+		if fv.IsNil() {
+			return PatchSkipped, nil
+		}
+		fv.Set(reflect.Zero(fv.Type()))
+		return PatchApplied, nil
+	}
+
+	if tv := reflect.ValueOf(target); tv.Type() == fv.Type() {
+		if !fv.IsNil() && reflect.DeepEqual(fv.Interface(), tv.Interface()) {
+			return PatchSkipped, nil
+		}
+		fv.Set(tv)
+		return PatchApplied, nil
+	}
+
+	elemType := fv.Type().Elem()
+	tv := reflect.ValueOf(target)
+	if !tv.Type().ConvertibleTo(elemType) {
+		return "", fmt.Errorf("diff: %w: cannot assign %s to %s", ErrTypeMismatch, tv.Type(), fv.Type())
+	}
+	tv = tv.Convert(elemType)
+
+	if !fv.IsNil() && reflect.DeepEqual(fv.Elem().Interface(), tv.Interface()) {
+		return PatchSkipped, nil
+	}
+	if fv.IsNil() {
+		fv.Set(reflect.New(elemType))
+	}
+	fv.Elem().Set(tv)
+	return PatchApplied, nil
+}
+
+// applySliceChange inserts or removes the slice element addressed by seg,
+// then writes the resulting slice back to parent.
+func applySliceChange(parent reflect.Value, tagName, seg string, c Change) (string, error) {
+	switch c.Type {
+	case CREATE:
+		return insertSliceElement(parent, tagName, seg, c.To)
+	case DELETE:
+		return removeSliceElement(parent, tagName, seg, c.From)
+	default:
+		return "", fmt.Errorf("diff: %w: cannot %s a slice element directly", ErrTypeMismatch, c.Type)
+	}
+}
+
+func insertSliceElement(parent reflect.Value, tagName, seg string, to interface{}) (string, error) {
+	elemType := parent.Type().Elem()
+	tv := reflect.ValueOf(to)
+	if !tv.Type().ConvertibleTo(elemType) {
+		return "", fmt.Errorf("diff: %w: cannot insert %s into %s", ErrTypeMismatch, tv.Type(), parent.Type())
+	}
+	elem := tv.Convert(elemType)
+
+	if identifierFieldIndex(elemType, tagName) >= 0 {
+		if _, found, err := locateSliceIndex(parent, tagName, seg); err != nil {
+			return "", err
+		} else if found {
+			return PatchSkipped, nil
+		}
+		parent.Set(reflect.Append(parent, elem))
+		return PatchApplied, nil
+	}
+
+	idx := parent.Len()
+	if i, err := strconv.Atoi(seg); err == nil && i >= 0 && i <= parent.Len() {
+		idx = i
+	}
+	if idx < parent.Len() && reflect.DeepEqual(parent.Index(idx).Interface(), elem.Interface()) {
+		return PatchSkipped, nil
+	}
+
+	ns := reflect.MakeSlice(parent.Type(), 0, parent.Len()+1)
+	ns = reflect.AppendSlice(ns, parent.Slice(0, idx))
+	ns = reflect.Append(ns, elem)
+	ns = reflect.AppendSlice(ns, parent.Slice(idx, parent.Len()))
+	parent.Set(ns)
+
+	return PatchApplied, nil
+}
+
+func removeSliceElement(parent reflect.Value, tagName, seg string, from interface{}) (string, error) {
+	idx, found, err := locateSliceIndex(parent, tagName, seg)
+	if err != nil {
+		return "", err
+	}
+	if !found {
+		return PatchSkipped, nil
+	}
+
+	ns := reflect.MakeSlice(parent.Type(), 0, parent.Len()-1)
+	ns = reflect.AppendSlice(ns, parent.Slice(0, idx))
+	ns = reflect.AppendSlice(ns, parent.Slice(idx+1, parent.Len()))
+	parent.Set(ns)
+
+	_ = from // matched by position/identifier above; value kept for symmetry with Change
+	return PatchApplied, nil
+}
+
+// locateSliceIndex resolves seg to an index in s: the index of the element
+// whose identifier field stringifies to seg, if the element type is
+// identifier-tagged, or seg parsed as a plain integer index otherwise.
+func locateSliceIndex(s reflect.Value, tagName, seg string) (int, bool, error) {
+	elemType := s.Type().Elem()
+
+	if idIdx := identifierFieldIndex(elemType, tagName); idIdx >= 0 {
+		for i := 0; i < s.Len(); i++ {
+			if fmt.Sprintf("%v", s.Index(i).Field(idIdx).Interface()) == seg {
+				return i, true, nil
+			}
+		}
+		return 0, false, nil
+	}
+
+	i, err := strconv.Atoi(seg)
+	if err != nil {
+		return 0, false, fmt.Errorf("diff: %w: non-numeric slice segment %q", ErrTypeMismatch, seg)
+	}
+	if i < 0 || i >= s.Len() {
+		return 0, false, nil
+	}
+	return i, true, nil
+}
+
+func fieldIndexByTag(t reflect.Type, tagName, name string) int {
+	for i := 0; i < t.NumField(); i++ {
+		opts := parseTag(t.Field(i).Tag.Get(tagName), t.Field(i).Name)
+		if !opts.Ignore && opts.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func isZero(v reflect.Value) bool {
+	return reflect.DeepEqual(v.Interface(), reflect.Zero(v.Type()).Interface())
+}
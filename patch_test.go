@@ -0,0 +1,117 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPatch(t *testing.T) {
+	cases := []struct {
+		Name string
+		A, B interface{}
+	}{
+		{"int-slice-insert", []int{1, 2, 3}, []int{1, 2, 3, 4}},
+		{"int-slice-delete", []int{1, 2, 3}, []int{1, 3}},
+		{"int-slice-insert-delete", []int{1, 2, 3}, []int{1, 3, 4}},
+		{"comparable-slice-insert", []tistruct{{"one", 1}}, []tistruct{{"one", 1}, {"two", 2}}},
+		{"comparable-slice-delete", []tistruct{{"one", 1}, {"two", 2}}, []tistruct{{"one", 1}}},
+		{"comparable-slice-update", []tistruct{{"one", 1}}, []tistruct{{"one", 50}}},
+		{"struct-string-update", tstruct{Name: "one"}, tstruct{Name: "two"}},
+		{"struct-map-update", tstruct{Map: map[string]string{"test": "123"}}, tstruct{Map: map[string]string{"test": "456"}}},
+		{"struct-string-pointer-update", tstruct{Pointer: sptr("test")}, tstruct{Pointer: sptr("test2")}},
+		{"struct-nil-string-pointer-update", tstruct{Pointer: nil}, tstruct{Pointer: sptr("test")}},
+		{"struct-generic-slice-insert", tstruct{Values: []string{"one"}}, tstruct{Values: []string{"one", "two"}}},
+		{"struct-identifiable-slice-insert", tstruct{Identifiables: []tistruct{{"one", 1}}}, tstruct{Identifiables: []tistruct{{"one", 1}, {"two", 2}}}},
+		{"struct-generic-slice-delete", tstruct{Values: []string{"one", "two"}}, tstruct{Values: []string{"one"}}},
+		{"struct-identifiable-slice-delete", tstruct{Identifiables: []tistruct{{"one", 1}, {"two", 2}}}, tstruct{Identifiables: []tistruct{{"one", 1}}}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			cl, err := Diff(tc.A, tc.B)
+			assert.NoError(t, err)
+
+			got := clone(t, tc.A)
+			pl, err := Patch(cl, got)
+			assert.NoError(t, err)
+			for _, e := range pl {
+				assert.NoError(t, e.Error)
+			}
+			assert.Equal(t, tc.B, derefIfNeeded(got))
+
+			back := clone(t, tc.B)
+			pl, err = Revert(cl, back)
+			assert.NoError(t, err)
+			for _, e := range pl {
+				assert.NoError(t, e.Error)
+			}
+			assert.Equal(t, tc.A, derefIfNeeded(back))
+		})
+	}
+}
+
+func TestPatchSkipsAlreadyAppliedChange(t *testing.T) {
+	cl, err := Diff(tstruct{Name: "one"}, tstruct{Name: "two"})
+	assert.NoError(t, err)
+
+	got := tstruct{Name: "two"}
+	pl, err := Patch(cl, &got)
+	assert.NoError(t, err)
+	assert.Equal(t, PatchSkipped, pl[0].Status)
+	assert.Equal(t, "two", got.Name)
+}
+
+func TestPatchReportsMissingPath(t *testing.T) {
+	cl := Changelog{{Type: UPDATE, Path: []string{"nope"}, From: "a", To: "b"}}
+
+	pl, err := Patch(cl, &tstruct{})
+	assert.NoError(t, err)
+	assert.Equal(t, PatchErrored, pl[0].Status)
+	assert.ErrorIs(t, pl[0].Error, ErrPathNotFound)
+}
+
+// clone returns a pointer to a fresh copy of v, suitable as a Patch/Revert
+// destination.
+func clone(t *testing.T, v interface{}) interface{} {
+	t.Helper()
+
+	switch vv := v.(type) {
+	case []int:
+		c := append([]int(nil), vv...)
+		return &c
+	case []string:
+		c := append([]string(nil), vv...)
+		return &c
+	case []tistruct:
+		c := append([]tistruct(nil), vv...)
+		return &c
+	case tstruct:
+		c := vv
+		if c.Pointer != nil {
+			p := *c.Pointer
+			c.Pointer = &p
+		}
+		return &c
+	default:
+		t.Fatalf("clone: unsupported type %T", v)
+		return nil
+	}
+}
+
+// derefIfNeeded unwraps the pointer produced by clone so it can be compared
+// against the original value-typed test fixtures.
+func derefIfNeeded(v interface{}) interface{} {
+	switch vv := v.(type) {
+	case *[]int:
+		return *vv
+	case *[]string:
+		return *vv
+	case *[]tistruct:
+		return *vv
+	case *tstruct:
+		return *vv
+	default:
+		return v
+	}
+}
@@ -0,0 +1,95 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Path is the location of a Change within the compared values: one segment
+// per struct field or slice element crossed to reach it. Maps are compared
+// as whole values, so a Path never holds a map key. It is the type of
+// Change.Path.
+type Path []string
+
+// Parse parses s, as produced by Path.String, back into a Path. Segments
+// are separated by an unescaped '.'; a literal '.' or '\' within a segment
+// must be written as '\.' or '\\', which is what String produces, so
+// Parse(p.String()) reproduces p regardless of what characters its
+// segments contain. The one exception: an empty Path and a Path of a
+// single empty segment both render as "", which parses to a nil Path.
+func Parse(s string) (Path, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	var segs []string
+	var cur strings.Builder
+	escaped := false
+
+	for _, r := range s {
+		switch {
+		case escaped:
+			cur.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '.':
+			segs = append(segs, cur.String())
+			cur.Reset()
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	if escaped {
+		return nil, fmt.Errorf("diff: path %q ends with a trailing escape", s)
+	}
+	segs = append(segs, cur.String())
+
+	return Path(segs), nil
+}
+
+// String renders p as a dot-separated path, escaping any '.' or '\'
+// within a segment so the result round-trips through Parse.
+func (p Path) String() string {
+	segs := make([]string, len(p))
+	for i, seg := range p {
+		segs[i] = escapePathSegment(seg)
+	}
+	return strings.Join(segs, ".")
+}
+
+func escapePathSegment(seg string) string {
+	seg = strings.ReplaceAll(seg, `\`, `\\`)
+	seg = strings.ReplaceAll(seg, ".", `\.`)
+	return seg
+}
+
+// JSONPointer renders p as an RFC 6901 JSON Pointer.
+func (p Path) JSONPointer() string {
+	return JSONPointer([]string(p))
+}
+
+// Match reports whether p matches pattern, a Path-syntax string (see
+// Parse) in which a segment of "*" matches any single segment of p. A
+// pattern and p must have the same number of segments to match.
+func (p Path) Match(pattern string) bool {
+	pat, err := Parse(pattern)
+	if err != nil {
+		return false
+	}
+
+	if len(pat) != len(p) {
+		return false
+	}
+
+	for i, seg := range pat {
+		if seg == "*" {
+			continue
+		}
+		if seg != p[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,79 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPathStringParseRoundTrip(t *testing.T) {
+	cases := []struct {
+		Name string
+		Path Path
+	}{
+		{"simple", Path{"name"}},
+		{"nested", Path{"values", "1"}},
+		{"identifier-two", Path{"identifiables", "two"}},
+		{"numeric-looking-identifier", Path{"identifiables", "123"}},
+		{"segment-with-dot", Path{"a.b", "c"}},
+		{"segment-with-slash", Path{"a/b", "c"}},
+		{"segment-with-backslash", Path{`a\b`, "c"}},
+		{"empty-path", Path(nil)},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			s := tc.Path.String()
+
+			got, err := Parse(s)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.Path, got)
+		})
+	}
+}
+
+func TestPathParseTrailingEscapeErrors(t *testing.T) {
+	_, err := Parse(`name\`)
+	assert.Error(t, err)
+}
+
+func TestPathJSONPointer(t *testing.T) {
+	assert.Equal(t, "/identifiables/two", Path{"identifiables", "two"}.JSONPointer())
+	assert.Equal(t, "/a~1b/c~0d", Path{"a/b", "c~d"}.JSONPointer())
+}
+
+func TestPathMatch(t *testing.T) {
+	cases := []struct {
+		Name    string
+		Path    Path
+		Pattern string
+		Want    bool
+	}{
+		{"exact", Path{"name"}, "name", true},
+		{"exact-mismatch", Path{"name"}, "value", false},
+		{"wildcard-segment", Path{"identifiables", "two", "value"}, "identifiables.*.value", true},
+		{"wildcard-no-match-other-field", Path{"identifiables", "two", "name"}, "identifiables.*.value", false},
+		{"length-mismatch", Path{"identifiables", "two"}, "identifiables.*.value", false},
+		{"wildcard-whole-path", Path{"values", "1"}, "*.*", true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			assert.Equal(t, tc.Want, tc.Path.Match(tc.Pattern))
+		})
+	}
+}
+
+func TestChangelogFilterAndFilterOut(t *testing.T) {
+	cl := Changelog{
+		{Type: UPDATE, Path: Path{"identifiables", "one", "value"}, From: 1, To: 2},
+		{Type: UPDATE, Path: Path{"identifiables", "one", "name"}, From: "one", To: "uno"},
+		{Type: UPDATE, Path: Path{"name"}, From: "a", To: "b"},
+	}
+
+	filtered := cl.Filter("identifiables.*.value")
+	assert.Equal(t, Changelog{cl[0]}, filtered)
+
+	filteredOut := cl.FilterOut("identifiables.*.value")
+	assert.Equal(t, Changelog{cl[1], cl[2]}, filteredOut)
+}
@@ -0,0 +1,167 @@
+package diff
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// diffSlice compares two slices (or arrays). Elements whose type carries an
+// `identifier` tagged field are matched by that identifier, so a CREATE or
+// DELETE reports the identifier value as the last path segment and a match
+// recurses to find field-level changes. Otherwise elements are matched by
+// their own value, and a CREATE or DELETE reports the index at which the
+// value appeared.
+func (d *Differ) diffSlice(path []string, cl *Changelog, a, b reflect.Value) error {
+	elemType := a.Type().Elem()
+
+	if idx := identifierFieldIndex(elemType, d.tagName); idx >= 0 {
+		return d.diffIdentifiableSlice(path, cl, a, b, idx)
+	}
+
+	if d.sliceOrdering == SliceMyers && elemType.Comparable() {
+		return d.diffMyersSlice(path, cl, a, b)
+	}
+
+	return d.diffValueKeyedSlice(path, cl, a, b)
+}
+
+// identifierFieldIndex returns the index of t's identifier-tagged field, or
+// -1 if t is not a struct or has none.
+func identifierFieldIndex(t reflect.Type, tagName string) int {
+	if t.Kind() != reflect.Struct {
+		return -1
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if parseTag(field.Tag.Get(tagName), field.Name).Identifier {
+			return i
+		}
+	}
+
+	return -1
+}
+
+type sliceEntry struct {
+	index int
+	value reflect.Value
+}
+
+// diffIdentifiableSlice matches elements of a and b by their identifier
+// field, recursing into matches and reporting unmatched elements as
+// CREATE/DELETE.
+func (d *Differ) diffIdentifiableSlice(path []string, cl *Changelog, a, b reflect.Value, idIdx int) error {
+	aByKey, aOrder := indexByIdentifier(a, idIdx)
+	bByKey, bOrder := indexByIdentifier(b, idIdx)
+
+	for _, key := range aOrder {
+		ae := aByKey[key]
+
+		be, ok := bByKey[key]
+		if !ok {
+			*cl = append(*cl, Change{Type: DELETE, Path: appendPath(path, key), From: ae.value.Interface()})
+			continue
+		}
+
+		if err := d.diff(appendPath(path, key), cl, ae.value, be.value); err != nil {
+			return err
+		}
+	}
+
+	for _, key := range bOrder {
+		if _, ok := aByKey[key]; ok {
+			continue
+		}
+
+		*cl = append(*cl, Change{Type: CREATE, Path: appendPath(path, key), To: bByKey[key].value.Interface()})
+	}
+
+	return nil
+}
+
+func indexByIdentifier(s reflect.Value, idIdx int) (map[string]sliceEntry, []string) {
+	byKey := make(map[string]sliceEntry, s.Len())
+	order := make([]string, s.Len())
+
+	for i := 0; i < s.Len(); i++ {
+		v := s.Index(i)
+		key := fmt.Sprintf("%v", v.Field(idIdx).Interface())
+		byKey[key] = sliceEntry{index: i, value: v}
+		order[i] = key
+	}
+
+	return byKey, order
+}
+
+// diffValueKeyedSlice matches elements of a and b by their own value,
+// treating equal values as unchanged regardless of position. Elements of a
+// with no corresponding value in b are reported as DELETE at their original
+// index; elements of b with no corresponding value in a are reported as
+// CREATE at their new index. Matching accounts for duplicate values via a
+// multiset so e.g. [1,2,3] -> [1,3,4] yields a delete of 2 and a create of 4
+// rather than two updates.
+func (d *Differ) diffValueKeyedSlice(path []string, cl *Changelog, a, b reflect.Value) error {
+	aKeys := sliceValueKeys(a)
+	bKeys := sliceValueKeys(b)
+
+	common := commonCounts(aKeys, bKeys)
+
+	remaining := cloneCounts(common)
+	for i, key := range aKeys {
+		if remaining[key] > 0 {
+			remaining[key]--
+			continue
+		}
+		*cl = append(*cl, Change{Type: DELETE, Path: appendPath(path, strconv.Itoa(i)), From: a.Index(i).Interface()})
+	}
+
+	remaining = cloneCounts(common)
+	for i, key := range bKeys {
+		if remaining[key] > 0 {
+			remaining[key]--
+			continue
+		}
+		*cl = append(*cl, Change{Type: CREATE, Path: appendPath(path, strconv.Itoa(i)), To: b.Index(i).Interface()})
+	}
+
+	return nil
+}
+
+func sliceValueKeys(s reflect.Value) []string {
+	keys := make([]string, s.Len())
+	for i := range keys {
+		keys[i] = fmt.Sprintf("%#v", s.Index(i).Interface())
+	}
+	return keys
+}
+
+func commonCounts(aKeys, bKeys []string) map[string]int {
+	aCount := counts(aKeys)
+	bCount := counts(bKeys)
+
+	common := make(map[string]int, len(aCount))
+	for k, n := range aCount {
+		if m := bCount[k]; m < n {
+			n = m
+		}
+		common[k] = n
+	}
+	return common
+}
+
+func counts(keys []string) map[string]int {
+	c := make(map[string]int, len(keys))
+	for _, k := range keys {
+		c[k]++
+	}
+	return c
+}
+
+func cloneCounts(c map[string]int) map[string]int {
+	cp := make(map[string]int, len(c))
+	for k, v := range c {
+		cp[k] = v
+	}
+	return cp
+}
@@ -0,0 +1,37 @@
+package diff
+
+import "strings"
+
+// tagOptions holds the parsed contents of a struct field's diff tag, e.g.
+// `diff:"name,identifier"`.
+type tagOptions struct {
+	Name       string
+	Identifier bool
+	Ignore     bool
+}
+
+// parseTag parses the value of a diff struct tag. defaultName is used when
+// the tag does not specify one.
+func parseTag(tag, defaultName string) tagOptions {
+	if tag == "" {
+		return tagOptions{Name: defaultName}
+	}
+
+	parts := strings.Split(tag, ",")
+	if parts[0] == "-" {
+		return tagOptions{Ignore: true}
+	}
+
+	opts := tagOptions{Name: parts[0]}
+	if opts.Name == "" {
+		opts.Name = defaultName
+	}
+
+	for _, p := range parts[1:] {
+		if p == "identifier" {
+			opts.Identifier = true
+		}
+	}
+
+	return opts
+}